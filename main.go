@@ -1,586 +1,1437 @@
-package main
-
-import (
-	"encoding/csv"
-	"errors"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-
-	"github.com/olekukonko/tablewriter"
-)
-
-func main() {
-	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer closeFile()
-
-	// Load and parse processes
-	processes, err := loadProcesses(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	//
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	//
-	RRSchedule(os.Stdout, "Round-robin", processes)
-}
-
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
-	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
-	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
-	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
-		}
-	}
-
-	return f, closeFn, nil
-}
-
-type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
-	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
-	}
-)
-
-//region Schedulers
-
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
-
-		start := waitingTime + processes[i].ArrivalTime
-
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
-	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-// go run main.go example_processes.csv
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	//store the original burst times, and use this to keep track of how long each process has left
-	procTimes := []int64{int64(processes[0].BurstDuration)}
-
-	for y := 1; y < len(processes); y++ {
-		procTimes = append(procTimes, processes[y].BurstDuration)
-	}
-
-	var totalLoopIterations = 0
-
-	var curProcess = 0
-	var shortestTime = 999999
-	var highestPriority = 999999
-
-	//keeps track of how much time we've spent on the current process
-	var curBurst = 0
-
-	//figure out how many times to run the loop based on how long each process needs
-	for z := range processes {
-		totalLoopIterations += int(processes[z].BurstDuration)
-	}
-
-	for i := 0; i <= totalLoopIterations; i++ {
-		serviceTime = int64(i)
-		//set shortest time to a very high number
-		shortestTime = 999999
-		highestPriority = 999999
-		//keep track of what process we had last loop
-		prevProc := curProcess
-
-		//find the process with the higest priority left to do
-		//if we find ones with the same priority, choose the shorter one
-		for j := range processes {
-			if processes[j].Priority < int64(highestPriority) && processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 {
-				highestPriority = int(processes[j].Priority)
-				curProcess = j
-				shortestTime = int(procTimes[j])
-			}
-			if processes[j].Priority == int64(highestPriority) && processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 && procTimes[j] < int64(shortestTime) {
-				highestPriority = int(processes[j].Priority)
-				curProcess = j
-				shortestTime = int(procTimes[j])
-			}
-		}
-
-		//reset the current burst if we change process
-		if prevProc != curProcess {
-			curBurst = 0
-		}
-
-		//take one away from the current process' time
-		//add one to the current burst streak
-		procTimes[curProcess] -= 1
-		curBurst += 1
-
-		//if a process finishes or a process is premepted
-		if procTimes[curProcess] == 0 || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
-
-			//if the process was preempted, add the preempted process to the gantt
-			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 && procTimes[curProcess] != 0 {
-				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
-
-				start := waitingTime + processes[prevProc].ArrivalTime
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[prevProc].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-			}
-
-			//if the process finished, add it to the gantt chart, and add it to the schedule
-			if procTimes[curProcess] == 0 {
-
-				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
-
-				start := waitingTime + processes[curProcess].ArrivalTime
-
-				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
-				totalWait += float64(waitingTime)
-
-				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
-				totalTurnaround += float64(turnaround)
-
-				completion := serviceTime + 1
-				lastCompletion = float64(completion)
-
-				schedule[curProcess] = []string{
-					fmt.Sprint(processes[curProcess].ProcessID),
-					fmt.Sprint(processes[curProcess].Priority),
-					fmt.Sprint(processes[curProcess].BurstDuration),
-					fmt.Sprint(processes[curProcess].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[curProcess].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-
-			}
-		}
-
-	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-
-}
-
-// go run main.go example_processes.csv
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	//store the original burst times, and use this to keep track of how long each process has left
-	procTimes := []int64{int64(processes[0].BurstDuration)}
-
-	for y := 1; y < len(processes); y++ {
-		procTimes = append(procTimes, processes[y].BurstDuration)
-	}
-
-	var totalLoopIterations = 0
-
-	var curProcess = 0
-	var shortestTime = 999999
-
-	//keeps track of how much time we've spent on the current process
-	var curBurst = 0
-
-	//figure out how many times to run the loop based on how long each process needs
-	for z := range processes {
-		totalLoopIterations += int(processes[z].BurstDuration)
-	}
-
-	for i := 0; i <= totalLoopIterations; i++ {
-		serviceTime = int64(i)
-		//set shortest time to a very high number
-		shortestTime = 999999
-		//keep track of what process we had last loop
-		prevProc := curProcess
-
-		//find the process with the shortest burst left to do, that has reached the scheduler
-		for j := range processes {
-			if processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 && procTimes[j] < int64(shortestTime) {
-				curProcess = j
-				shortestTime = int(procTimes[j])
-			}
-		}
-
-		//reset the current burst if we change process
-		if prevProc != curProcess {
-			curBurst = 0
-		}
-
-		//take one away from the current process' time
-		//add one to the current burst streak
-		procTimes[curProcess] -= 1
-		curBurst += 1
-
-		//if a process finishes or a process is premepted
-		if procTimes[curProcess] == 0 || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
-
-			//if the process was preempted, add the preempted process to the gantt
-			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 && procTimes[curProcess] != 0 {
-				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
-
-				start := waitingTime + processes[prevProc].ArrivalTime
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[prevProc].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-			}
-
-			//if the process finished, add it to the gantt chart, and add it to the schedule
-			if procTimes[curProcess] == 0 {
-
-				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
-
-				start := waitingTime + processes[curProcess].ArrivalTime
-
-				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
-				totalWait += float64(waitingTime)
-
-				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
-				totalTurnaround += float64(turnaround)
-
-				completion := serviceTime + 1
-				lastCompletion = float64(completion)
-
-				schedule[curProcess] = []string{
-					fmt.Sprint(processes[curProcess].ProcessID),
-					fmt.Sprint(processes[curProcess].Priority),
-					fmt.Sprint(processes[curProcess].BurstDuration),
-					fmt.Sprint(processes[curProcess].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[curProcess].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-
-			}
-
-		}
-
-	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-// go run main.go example_processes.csv
-func RRSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	//timeslice
-	const TIMESLICE = 4
-
-	//store the original burst times, and use this to keep track of how long each process has left
-	procTimes := []int64{int64(processes[0].BurstDuration)}
-
-	for y := 1; y < len(processes); y++ {
-		procTimes = append(procTimes, processes[y].BurstDuration)
-	}
-
-	var totalLoopIterations = 0
-
-	var curProcess = 0
-
-	//keeps track of how much time we've spent on the current process
-	var curBurst = 0
-
-	//figure out how many times to run the loop based on how long each process needs
-	for z := range processes {
-		totalLoopIterations += int(processes[z].BurstDuration)
-	}
-
-	for i := 0; i < totalLoopIterations; i++ {
-		serviceTime = int64(i)
-
-		//keep track of what process we had last loop
-		prevProc := curProcess
-
-		//if a process finishes or the timeslice is over, switch
-		if curBurst == TIMESLICE || procTimes[curProcess] == 0 {
-			curProcess += 1
-			curBurst = 0
-			//if we were at the last process, change to the first process
-			if curProcess >= len(processes) {
-				curProcess = 0
-			}
-
-			//make sure the process hasn't finished, if it has, keep going till we find the next one
-			for procTimes[curProcess] == 0 {
-				curProcess += 1
-
-				if curProcess >= len(processes) {
-					curProcess = 0
-				}
-			}
-
-		}
-
-		//take one away from the current process' time
-		//add one to the current burst streak
-		procTimes[curProcess] -= 1
-		curBurst += 1
-
-		//if a process finishes or a process is premepted
-		if procTimes[curProcess] == 0 || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
-
-			//if the process was preempted, add the preempted process to the gantt
-			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 {
-				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
-
-				start := serviceTime - TIMESLICE
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[prevProc].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-			}
-
-			//if the process finished, add it to the gantt chart, and add it to the schedule
-			if procTimes[curProcess] == 0 {
-
-				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
-
-				start := waitingTime + processes[curProcess].ArrivalTime
-
-				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
-				totalWait += float64(waitingTime)
-
-				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
-				totalTurnaround += float64(turnaround)
-
-				completion := serviceTime + 1
-				lastCompletion = float64(completion)
-
-				schedule[curProcess] = []string{
-					fmt.Sprint(processes[curProcess].ProcessID),
-					fmt.Sprint(processes[curProcess].Priority),
-					fmt.Sprint(processes[curProcess].BurstDuration),
-					fmt.Sprint(processes[curProcess].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-
-				gantt = append(gantt, TimeSlice{
-					PID:   processes[curProcess].ProcessID,
-					Start: start,
-					Stop:  serviceTime + 1,
-				})
-
-			}
-
-		}
-
-	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-//endregion
-
-//region Output helpers
-
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-}
-
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
-	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
-		}
-	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
-
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
-}
-
-//endregion
-
-//region Loading processes.
-
-var ErrInvalidArgs = errors.New("invalid args")
-
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
-	}
-
-	return processes, nil
-}
-
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-
-	return i
-}
-
-//endregion
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Sheik13/4600-Scheduler/workload"
+	"github.com/olekukonko/tablewriter"
+)
+
+func main() {
+	var (
+		generate       = flag.Int("generate", 0, "generate N synthetic processes instead of reading a CSV file")
+		seed           = flag.Int64("seed", 1, "seed for the synthetic workload generator")
+		lambda         = flag.Float64("lambda", 0.5, "Poisson arrival rate (λ) for the synthetic workload generator")
+		mean           = flag.Float64("mean", 6, "mean burst duration for the synthetic workload generator")
+		priorityLevels = flag.Int("priority-levels", 0, "assign generated processes a uniform-random priority in [0, N); 0 leaves priority 0")
+		ioBurstProb    = flag.Float64("io-burst-prob", 0, "probability a generated process' burst is split around an I/O phase")
+		format         = flag.String("format", "text", "output format: text, json, or csv")
+		rankBy         = flag.String("rank-by", "wait", "column to rank the comparison table by: wait, turnaround, throughput, utilization, or switches")
+		csCost         = flag.Int64("context-switch-cost", 0, "ticks of overhead charged to the Gantt chart whenever the SJF, priority, or round-robin scheduler switches processes")
+	)
+	flag.Parse()
+
+	ContextSwitchCost = *csCost
+
+	processes, err := loadOrGenerateProcesses(*generate, workload.WorkloadConfig{
+		Seed:           *seed,
+		ArrivalRate:    *lambda,
+		MeanBurst:      *mean,
+		PriorityLevels: *priorityLevels,
+		IOBurstProb:    *ioBurstProb,
+	}, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reporter, err := newReporter(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schedulers := []struct {
+		name string
+		run  func([]Process) (Metrics, []TimeSlice, error)
+	}{
+		{"First-come, first-serve", FCFSSchedule},
+		{"Shortest-job-first", SJFSchedule},
+		{"Priority", SJFPrioritySchedule},
+		{"Round-robin", RRSchedule},
+		{"Multilevel feedback queue", MLFQSchedule},
+	}
+
+	algRuns := make([]AlgorithmRun, 0, len(schedulers))
+	for _, s := range schedulers {
+		metrics, gantt, err := s.run(processes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reporter.Report(os.Stdout, s.name, processes, gantt, metrics)
+		algRuns = append(algRuns, AlgorithmRun{Name: s.name, Metrics: metrics})
+	}
+
+	if err := reporter.Flush(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+
+	// The comparison table is rendered output, not structured data, so it
+	// only makes sense alongside the text Reporter; json consumers already
+	// get every run's Metrics, and csv consumers get every run's
+	// per-process schedule rows (not the Metrics) instead.
+	if *format == "" || *format == "text" {
+		CompareRuns(os.Stdout, algRuns, *rankBy)
+	}
+}
+
+// loadOrGenerateProcesses returns a synthetic workload when generate > 0,
+// otherwise it falls back to reading the CSV file named in args.
+func loadOrGenerateProcesses(generate int, cfg workload.WorkloadConfig, args []string) ([]Process, error) {
+	if generate > 0 {
+		cfg.N = generate
+		return fromWorkloadProcesses(workload.GenerateProcesses(cfg)), nil
+	}
+
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: must give a scheduling file to process, or --generate N", ErrInvalidArgs)
+	}
+
+	f, closeFile, err := openProcessingFile(os.Args[0], args[0])
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+
+	return loadProcesses(f)
+}
+
+// fromWorkloadProcesses converts a workload.Process slice into the
+// scheduler's own Process type.
+func fromWorkloadProcesses(ws []workload.Process) []Process {
+	processes := make([]Process, len(ws))
+	for i := range ws {
+		processes[i] = Process{
+			ProcessID:     ws[i].ProcessID,
+			ArrivalTime:   ws[i].ArrivalTime,
+			BurstDuration: ws[i].BurstDuration,
+			Priority:      ws[i].Priority,
+			IOBursts:      ws[i].IOBursts,
+		}
+	}
+	return processes
+}
+
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing scheduling file", err)
+		}
+	}
+
+	return f, closeFn, nil
+}
+
+type (
+	Process struct {
+		ProcessID     int64 `json:"process_id"`
+		ArrivalTime   int64 `json:"arrival_time"`
+		BurstDuration int64 `json:"burst_duration"`
+		Priority      int64 `json:"priority"`
+		// IOBursts, when set, alternates CPU and I/O phases (CPU, IO, CPU, IO, ...)
+		// instead of treating BurstDuration as one uninterrupted run. In
+		// SJFSchedule, SJFPrioritySchedule, and RRSchedule a process leaves
+		// the ready queue for each IO phase's duration and re-enters once it
+		// elapses; FCFSSchedule and MLFQSchedule ignore IOBursts and run
+		// BurstDuration as one uninterrupted burst.
+		IOBursts []int64 `json:"io_bursts,omitempty"`
+	}
+	TimeSlice struct {
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	}
+	// Metrics bundles the summary statistics computed for a single
+	// scheduler run, beyond the per-process wait/turnaround/exit columns
+	// already in the schedule table.
+	Metrics struct {
+		AveWait         float64 `json:"ave_wait"`
+		AveTurnaround   float64 `json:"ave_turnaround"`
+		Throughput      float64 `json:"throughput"`
+		AveResponse     float64 `json:"ave_response"`
+		CPUUtilization  float64 `json:"cpu_utilization"`
+		ContextSwitches int     `json:"context_switches"`
+		Turnarounds     []int64 `json:"turnarounds"`
+	}
+)
+
+// ContextSwitchCost is the number of idle ticks inserted into the Gantt
+// chart of SJFSchedule, SJFPrioritySchedule, and RRSchedule every time the
+// running process changes, modeling the overhead real OS context switches
+// impose. It defaults to 0 (no overhead) and is set from the
+// --context-switch-cost flag.
+var ContextSwitchCost int64
+
+// ioTracker tracks, for every process in a run, which CPU/IO phase it is
+// in and when it next becomes ready, so the tick-based schedulers can
+// treat a process mid-I/O-burst as not-ready without otherwise changing
+// their dispatch logic. A process with no IOBursts is modeled as a
+// single CPU phase equal to its BurstDuration, i.e. it behaves exactly as
+// before.
+type ioTracker struct {
+	phases    [][]int64
+	phaseIdx  []int
+	remaining []int64
+	ioUntil   []int64
+}
+
+func newIOTracker(processes []Process) *ioTracker {
+	t := &ioTracker{
+		phases:    make([][]int64, len(processes)),
+		phaseIdx:  make([]int, len(processes)),
+		remaining: make([]int64, len(processes)),
+		ioUntil:   make([]int64, len(processes)),
+	}
+	for i := range processes {
+		if len(processes[i].IOBursts) > 0 {
+			t.phases[i] = processes[i].IOBursts
+		} else {
+			t.phases[i] = []int64{processes[i].BurstDuration}
+		}
+		t.remaining[i] = t.phases[i][0]
+	}
+	return t
+}
+
+// ready reports whether process j is out of I/O and able to run at tick.
+func (t *ioTracker) ready(j int, tick int64) bool {
+	return t.ioUntil[j] <= tick
+}
+
+// ioTicks returns the total ticks a run's processes spend in I/O, used to
+// size the dispatcher loops' iteration budget.
+func (t *ioTracker) ioTicks() int64 {
+	var total int64
+	for _, phases := range t.phases {
+		for i := 1; i < len(phases); i += 2 {
+			total += phases[i]
+		}
+	}
+	return total
+}
+
+// tock records one tick of CPU execution for process j at the given
+// tick. It returns true if that tick exhausted the process' current CPU
+// phase and handed it off to an I/O burst - the caller should treat that
+// like a preemption, not a completion, even if the process' total
+// remaining burst is still nonzero.
+func (t *ioTracker) tock(j int, tick int64) bool {
+	t.remaining[j]--
+	if t.remaining[j] > 0 {
+		return false
+	}
+
+	next := t.phaseIdx[j] + 1
+	if next >= len(t.phases[j]) {
+		return false // last CPU phase ended: a real completion
+	}
+
+	t.ioUntil[j] = tick + 1 + t.phases[j][next]
+	t.phaseIdx[j] = next + 1
+	if t.phaseIdx[j] < len(t.phases[j]) {
+		t.remaining[j] = t.phases[j][t.phaseIdx[j]]
+	}
+	return true
+}
+
+// maxArrivalTime returns the latest ArrivalTime among processes, used to
+// size the dispatch loops' iteration budget so a late arrival isn't cut
+// off before it ever gets a turn.
+func maxArrivalTime(processes []Process) int64 {
+	var max int64
+	for _, p := range processes {
+		if p.ArrivalTime > max {
+			max = p.ArrivalTime
+		}
+	}
+	return max
+}
+
+//region Schedulers
+
+// FCFSSchedule runs first-come, first-serve scheduling over a slice of
+// processes and returns the run's Metrics and Gantt chart so the caller
+// can render them through a Reporter.
+func FCFSSchedule(processes []Process) (Metrics, []TimeSlice, error) {
+	if len(processes) == 0 {
+		return Metrics{}, nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([][]string, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+	for i := range processes {
+		if processes[i].ArrivalTime > 0 {
+			waitingTime = serviceTime - processes[i].ArrivalTime
+		}
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		lastCompletion = float64(completion)
+
+		schedule[i] = []string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].Priority),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+			fmt.Sprint(waitingTime),
+			fmt.Sprint(turnaround),
+			fmt.Sprint(completion),
+		}
+		serviceTime += processes[i].BurstDuration
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	metrics := computeMetrics(processes, schedule, gantt, totalTurnaround, lastCompletion)
+
+	return metrics, gantt, nil
+}
+
+// go run main.go example_processes.csv
+
+func SJFPrioritySchedule(processes []Process) (Metrics, []TimeSlice, error) {
+	if len(processes) == 0 {
+		return Metrics{}, nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([][]string, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	//store the original burst times, and use this to keep track of how long each process has left
+	procTimes := []int64{int64(processes[0].BurstDuration)}
+
+	for y := 1; y < len(processes); y++ {
+		procTimes = append(procTimes, processes[y].BurstDuration)
+	}
+
+	io := newIOTracker(processes)
+	var csOffset int64
+
+	var totalLoopIterations = 0
+
+	var curProcess = 0
+	var shortestTime = 999999
+	var highestPriority = 999999
+
+	//keeps track of how much time we've spent on the current process
+	var curBurst = 0
+
+	//figure out how many times to run the loop based on how long each process needs,
+	//plus slack for I/O waits and the latest arrival so a late process isn't cut off
+	for z := range processes {
+		totalLoopIterations += int(processes[z].BurstDuration)
+	}
+	totalLoopIterations += int(maxArrivalTime(processes)) + int(io.ioTicks()) + len(processes)
+
+	for i := 0; i <= totalLoopIterations; i++ {
+		//keep track of what process we had last loop
+		prevProc := curProcess
+		found := false
+
+		//find the process with the higest priority left to do, that isn't mid-I/O-burst
+		//if we find ones with the same priority, choose the shorter one
+		shortestTime = 999999
+		highestPriority = 999999
+		for j := range processes {
+			if !io.ready(j, int64(i)) {
+				continue
+			}
+			if processes[j].Priority < int64(highestPriority) && processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 {
+				highestPriority = int(processes[j].Priority)
+				curProcess = j
+				shortestTime = int(procTimes[j])
+				found = true
+			}
+			if processes[j].Priority == int64(highestPriority) && processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 && procTimes[j] < int64(shortestTime) {
+				highestPriority = int(processes[j].Priority)
+				curProcess = j
+				shortestTime = int(procTimes[j])
+				found = true
+			}
+		}
+
+		//nobody is ready (not yet arrived, or mid-I/O-burst): idle this tick
+		if !found {
+			continue
+		}
+
+		//a context switch inserts ContextSwitchCost idle ticks before the new process starts
+		if i > 0 && prevProc != curProcess && ContextSwitchCost > 0 {
+			csStart := int64(i) + csOffset
+			gantt = append(gantt, TimeSlice{PID: -1, Start: csStart, Stop: csStart + ContextSwitchCost})
+			csOffset += ContextSwitchCost
+		}
+		serviceTime = int64(i) + csOffset
+
+		//reset the current burst if we change process
+		if prevProc != curProcess {
+			curBurst = 0
+		}
+
+		//take one away from the current process' time
+		//add one to the current burst streak
+		procTimes[curProcess] -= 1
+		curBurst += 1
+		blockedForIO := io.tock(curProcess, serviceTime)
+
+		//if a process finishes or a process is premepted
+		if (procTimes[curProcess] == 0 && !blockedForIO) || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
+
+			//if the process was preempted, add the preempted process to the gantt
+			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 && procTimes[curProcess] != 0 {
+				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
+
+				start := waitingTime + processes[prevProc].ArrivalTime
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[prevProc].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+			}
+
+			//if the process finished, add it to the gantt chart, and add it to the schedule
+			if procTimes[curProcess] == 0 {
+
+				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
+
+				start := waitingTime + processes[curProcess].ArrivalTime
+
+				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
+				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
+				totalTurnaround += float64(turnaround)
+
+				completion := serviceTime + 1
+				lastCompletion = float64(completion)
+
+				schedule[curProcess] = []string{
+					fmt.Sprint(processes[curProcess].ProcessID),
+					fmt.Sprint(processes[curProcess].Priority),
+					fmt.Sprint(processes[curProcess].BurstDuration),
+					fmt.Sprint(processes[curProcess].ArrivalTime),
+					fmt.Sprint(waitingTime),
+					fmt.Sprint(turnaround),
+					fmt.Sprint(completion),
+				}
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[curProcess].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+
+			}
+		}
+
+	}
+
+	metrics := computeMetrics(processes, schedule, gantt, totalTurnaround, lastCompletion)
+
+	return metrics, gantt, nil
+}
+
+// go run main.go example_processes.csv
+func SJFSchedule(processes []Process) (Metrics, []TimeSlice, error) {
+	if len(processes) == 0 {
+		return Metrics{}, nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([][]string, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	//store the original burst times, and use this to keep track of how long each process has left
+	procTimes := []int64{int64(processes[0].BurstDuration)}
+
+	for y := 1; y < len(processes); y++ {
+		procTimes = append(procTimes, processes[y].BurstDuration)
+	}
+
+	io := newIOTracker(processes)
+	var csOffset int64
+
+	var totalLoopIterations = 0
+
+	var curProcess = 0
+	var shortestTime = 999999
+
+	//keeps track of how much time we've spent on the current process
+	var curBurst = 0
+
+	//figure out how many times to run the loop based on how long each process needs,
+	//plus slack for I/O waits and the latest arrival so a late process isn't cut off
+	for z := range processes {
+		totalLoopIterations += int(processes[z].BurstDuration)
+	}
+	totalLoopIterations += int(maxArrivalTime(processes)) + int(io.ioTicks()) + len(processes)
+
+	for i := 0; i <= totalLoopIterations; i++ {
+		//keep track of what process we had last loop
+		prevProc := curProcess
+		found := false
+
+		//find the process with the shortest burst left to do, that has reached the scheduler and isn't mid-I/O-burst
+		shortestTime = 999999
+		for j := range processes {
+			if io.ready(j, int64(i)) && processes[j].ArrivalTime <= int64(i) && procTimes[j] > 0 && procTimes[j] < int64(shortestTime) {
+				curProcess = j
+				shortestTime = int(procTimes[j])
+				found = true
+			}
+		}
+
+		//nobody is ready (not yet arrived, or mid-I/O-burst): idle this tick
+		if !found {
+			continue
+		}
+
+		//a context switch inserts ContextSwitchCost idle ticks before the new process starts
+		if i > 0 && prevProc != curProcess && ContextSwitchCost > 0 {
+			csStart := int64(i) + csOffset
+			gantt = append(gantt, TimeSlice{PID: -1, Start: csStart, Stop: csStart + ContextSwitchCost})
+			csOffset += ContextSwitchCost
+		}
+		serviceTime = int64(i) + csOffset
+
+		//reset the current burst if we change process
+		if prevProc != curProcess {
+			curBurst = 0
+		}
+
+		//take one away from the current process' time
+		//add one to the current burst streak
+		procTimes[curProcess] -= 1
+		curBurst += 1
+		blockedForIO := io.tock(curProcess, serviceTime)
+
+		//if a process finishes or a process is premepted
+		if (procTimes[curProcess] == 0 && !blockedForIO) || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
+
+			//if the process was preempted, add the preempted process to the gantt
+			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 && procTimes[curProcess] != 0 {
+				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
+
+				start := waitingTime + processes[prevProc].ArrivalTime
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[prevProc].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+			}
+
+			//if the process finished, add it to the gantt chart, and add it to the schedule
+			if procTimes[curProcess] == 0 {
+
+				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
+
+				start := waitingTime + processes[curProcess].ArrivalTime
+
+				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
+				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
+				totalTurnaround += float64(turnaround)
+
+				completion := serviceTime + 1
+				lastCompletion = float64(completion)
+
+				schedule[curProcess] = []string{
+					fmt.Sprint(processes[curProcess].ProcessID),
+					fmt.Sprint(processes[curProcess].Priority),
+					fmt.Sprint(processes[curProcess].BurstDuration),
+					fmt.Sprint(processes[curProcess].ArrivalTime),
+					fmt.Sprint(waitingTime),
+					fmt.Sprint(turnaround),
+					fmt.Sprint(completion),
+				}
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[curProcess].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+
+			}
+
+		}
+
+	}
+
+	metrics := computeMetrics(processes, schedule, gantt, totalTurnaround, lastCompletion)
+
+	return metrics, gantt, nil
+}
+
+// go run main.go example_processes.csv
+func RRSchedule(processes []Process) (Metrics, []TimeSlice, error) {
+	if len(processes) == 0 {
+		return Metrics{}, nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([][]string, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	//timeslice
+	const TIMESLICE = 4
+
+	//store the original burst times, and use this to keep track of how long each process has left
+	procTimes := []int64{int64(processes[0].BurstDuration)}
+
+	for y := 1; y < len(processes); y++ {
+		procTimes = append(procTimes, processes[y].BurstDuration)
+	}
+
+	io := newIOTracker(processes)
+	var csOffset int64
+
+	var totalLoopIterations = 0
+
+	var curProcess = 0
+
+	//keeps track of how much time we've spent on the current process
+	var curBurst = 0
+
+	//figure out how many times to run the loop based on how long each process needs,
+	//plus slack for I/O waits and the latest arrival so a late process isn't cut off
+	for z := range processes {
+		totalLoopIterations += int(processes[z].BurstDuration)
+	}
+	totalLoopIterations += int(maxArrivalTime(processes)) + int(io.ioTicks()) + len(processes)
+
+	for i := 0; i < totalLoopIterations; i++ {
+		//keep track of what process we had last loop
+		prevProc := curProcess
+		//ticks the outgoing process actually ran this streak, for the preempted gantt entry below
+		outgoingBurst := curBurst
+
+		//if a process finishes, the timeslice is over, or it's mid-I/O-burst, switch
+		if curBurst == TIMESLICE || procTimes[curProcess] == 0 || !io.ready(curProcess, int64(i)) {
+			outgoingBurst = curBurst
+			curProcess += 1
+			curBurst = 0
+			//if we were at the last process, change to the first process
+			if curProcess >= len(processes) {
+				curProcess = 0
+			}
+
+			//make sure the process hasn't finished and isn't mid-I/O-burst, keep going till we find the next one
+			attempts := 0
+			for (procTimes[curProcess] == 0 || !io.ready(curProcess, int64(i))) && attempts < len(processes) {
+				curProcess += 1
+
+				if curProcess >= len(processes) {
+					curProcess = 0
+				}
+				attempts++
+			}
+		}
+
+		//everyone is either finished or mid-I/O-burst: idle this tick
+		if procTimes[curProcess] == 0 || !io.ready(curProcess, int64(i)) {
+			continue
+		}
+
+		//a context switch inserts ContextSwitchCost idle ticks before the new process starts
+		if i > 0 && prevProc != curProcess && ContextSwitchCost > 0 {
+			csStart := int64(i) + csOffset
+			gantt = append(gantt, TimeSlice{PID: -1, Start: csStart, Stop: csStart + ContextSwitchCost})
+			csOffset += ContextSwitchCost
+		}
+		serviceTime = int64(i) + csOffset
+
+		//take one away from the current process' time
+		//add one to the current burst streak
+		procTimes[curProcess] -= 1
+		curBurst += 1
+		blockedForIO := io.tock(curProcess, serviceTime)
+
+		//if a process finishes or a process is premepted
+		if (procTimes[curProcess] == 0 && !blockedForIO) || (i > 0 && prevProc != curProcess && procTimes[prevProc] != 0) {
+
+			//if the process was preempted, add the preempted process to the gantt
+			if i > 0 && prevProc != curProcess && procTimes[prevProc] != 0 {
+				waitingTime = serviceTime - (processes[prevProc].ArrivalTime + (processes[prevProc].BurstDuration - procTimes[prevProc]))
+
+				start := serviceTime - int64(outgoingBurst)
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[prevProc].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+			}
+
+			//if the process finished, add it to the gantt chart, and add it to the schedule
+			if procTimes[curProcess] == 0 {
+
+				waitingTime = serviceTime - (processes[curProcess].ArrivalTime + int64(curBurst)) + 1
+
+				start := waitingTime + processes[curProcess].ArrivalTime
+
+				//waitingTime = serviceTime - (processes[curProcess].ArrivalTime + processes[curProcess].BurstDuration) + 1
+				turnaround := serviceTime + 1 - processes[curProcess].ArrivalTime
+				totalTurnaround += float64(turnaround)
+
+				completion := serviceTime + 1
+				lastCompletion = float64(completion)
+
+				schedule[curProcess] = []string{
+					fmt.Sprint(processes[curProcess].ProcessID),
+					fmt.Sprint(processes[curProcess].Priority),
+					fmt.Sprint(processes[curProcess].BurstDuration),
+					fmt.Sprint(processes[curProcess].ArrivalTime),
+					fmt.Sprint(waitingTime),
+					fmt.Sprint(turnaround),
+					fmt.Sprint(completion),
+				}
+
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[curProcess].ProcessID,
+					Start: start,
+					Stop:  serviceTime + 1,
+				})
+
+			}
+
+		}
+
+	}
+
+	metrics := computeMetrics(processes, schedule, gantt, totalTurnaround, lastCompletion)
+
+	return metrics, gantt, nil
+}
+
+// MLFQConfig controls the shape of a multilevel feedback queue: how many
+// priority levels exist, the time quantum granted at each level, and how
+// often all jobs are boosted back to the top level to avoid starvation.
+type MLFQConfig struct {
+	Quanta        []int64
+	BoostInterval int64
+	NumQueues     int
+}
+
+// DefaultMLFQConfig is a reasonable 3-level queue (quanta 2/4/8) with a
+// boost every 50 ticks, used by MLFQSchedule.
+var DefaultMLFQConfig = MLFQConfig{
+	Quanta:        []int64{2, 4, 8},
+	BoostInterval: 50,
+	NumQueues:     3,
+}
+
+// mlfqJob tracks the per-process bookkeeping the dispatcher needs that
+// doesn't belong on the Process type itself.
+type mlfqJob struct {
+	remaining  int64
+	level      int
+	sliceUsed  int64
+	started    bool
+	firstStart int64
+	completion int64
+}
+
+// MLFQSchedule runs a multilevel feedback queue over a slice of processes
+// and returns the run's Metrics and Gantt chart so the caller can render
+// them through a Reporter.
+//
+// New arrivals enter the top queue (level 0). A job that exhausts its
+// quantum is demoted one level; a job that finishes before its quantum is
+// up keeps its level. Every DefaultMLFQConfig.BoostInterval ticks, every
+// waiting job is promoted back to level 0 so long jobs can't starve short
+// ones. A process' CSV Priority, when it is a valid level (0 to
+// NumQueues-1), is used as its initial queue level; any other Priority
+// starts the process at level 0.
+func MLFQSchedule(processes []Process) (Metrics, []TimeSlice, error) {
+	return MLFQScheduleWithConfig(processes, DefaultMLFQConfig)
+}
+
+// MLFQScheduleWithConfig is MLFQSchedule with an explicit MLFQConfig,
+// letting callers tune the number of queues, their quanta, and the boost
+// interval.
+func MLFQScheduleWithConfig(processes []Process, cfg MLFQConfig) (Metrics, []TimeSlice, error) {
+	if len(processes) == 0 {
+		return Metrics{}, nil, fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	}
+
+	var (
+		totalTurnaround float64
+		lastCompletion  float64
+		schedule        = make([][]string, len(processes))
+		gantt           = make([]TimeSlice, 0)
+		occupancy       = make([]int64, cfg.NumQueues)
+	)
+
+	jobs := make([]mlfqJob, len(processes))
+	queues := make([][]int, cfg.NumQueues)
+
+	for i := range processes {
+		level := 0
+		if processes[i].Priority > 0 && int(processes[i].Priority) < cfg.NumQueues {
+			level = int(processes[i].Priority)
+		}
+		jobs[i] = mlfqJob{remaining: processes[i].BurstDuration, level: level, firstStart: -1}
+	}
+
+	var totalBurst int64
+	for i := range processes {
+		totalBurst += processes[i].BurstDuration
+	}
+	maxArrival := maxArrivalTime(processes)
+
+	var (
+		curProcess   = -1
+		curStart     int64
+		tick         int64
+		enqueued     = make([]bool, len(processes))
+		finished     int
+		lastBoost    int64
+		arrivalsLeft = len(processes)
+	)
+
+	// The bound must cover the latest arrival plus every tick of work, not
+	// just totalBurst, or a late-arriving process is silently dropped.
+	for finished < len(processes) && tick < maxArrival+totalBurst+1 {
+		// Admit any processes that have just arrived.
+		for i := range processes {
+			if !enqueued[i] && processes[i].ArrivalTime <= tick {
+				queues[jobs[i].level] = append(queues[jobs[i].level], i)
+				enqueued[i] = true
+				arrivalsLeft--
+			}
+		}
+
+		// Periodic priority boost: move everything waiting back to level 0.
+		if cfg.BoostInterval > 0 && tick > 0 && tick-lastBoost >= cfg.BoostInterval {
+			for lvl := 1; lvl < cfg.NumQueues; lvl++ {
+				for _, idx := range queues[lvl] {
+					jobs[idx].level = 0
+					jobs[idx].sliceUsed = 0
+					queues[0] = append(queues[0], idx)
+				}
+				queues[lvl] = nil
+			}
+			if curProcess != -1 && jobs[curProcess].level != 0 {
+				jobs[curProcess].level = 0
+				jobs[curProcess].sliceUsed = 0
+			}
+			lastBoost = tick
+		}
+
+		// Dispatch a new process if the CPU is idle.
+		if curProcess == -1 {
+			for lvl := 0; lvl < cfg.NumQueues; lvl++ {
+				if len(queues[lvl]) > 0 {
+					curProcess = queues[lvl][0]
+					queues[lvl] = queues[lvl][1:]
+					curStart = tick
+					break
+				}
+			}
+		}
+
+		if curProcess == -1 {
+			// Nobody is ready yet; idle for a tick.
+			tick++
+			continue
+		}
+
+		if !jobs[curProcess].started {
+			jobs[curProcess].started = true
+			jobs[curProcess].firstStart = tick
+		}
+
+		occupancy[jobs[curProcess].level]++
+		jobs[curProcess].remaining--
+		jobs[curProcess].sliceUsed++
+		tick++
+
+		quantum := cfg.Quanta[jobs[curProcess].level]
+		finishedJob := jobs[curProcess].remaining == 0
+		quantumUp := jobs[curProcess].sliceUsed >= quantum
+
+		if finishedJob || quantumUp {
+			gantt = append(gantt, TimeSlice{PID: processes[curProcess].ProcessID, Start: curStart, Stop: tick})
+
+			if finishedJob {
+				jobs[curProcess].completion = tick
+				completion := tick
+				turnaround := completion - processes[curProcess].ArrivalTime
+				waitingTime := turnaround - processes[curProcess].BurstDuration
+				totalTurnaround += float64(turnaround)
+				lastCompletion = float64(completion)
+
+				schedule[curProcess] = []string{
+					fmt.Sprint(processes[curProcess].ProcessID),
+					fmt.Sprint(jobs[curProcess].level),
+					fmt.Sprint(processes[curProcess].BurstDuration),
+					fmt.Sprint(processes[curProcess].ArrivalTime),
+					fmt.Sprint(waitingTime),
+					fmt.Sprint(turnaround),
+					fmt.Sprint(completion),
+				}
+				finished++
+				curProcess = -1
+			} else {
+				// Quantum exhausted before finishing: demote one level.
+				if jobs[curProcess].level < cfg.NumQueues-1 {
+					jobs[curProcess].level++
+				}
+				jobs[curProcess].sliceUsed = 0
+				queues[jobs[curProcess].level] = append(queues[jobs[curProcess].level], curProcess)
+				curProcess = -1
+			}
+		}
+	}
+
+	metrics := computeMetrics(processes, schedule, gantt, totalTurnaround, lastCompletion)
+
+	// Queue occupancy is MLFQ-specific diagnostic output, not part of the
+	// common Metrics shape the Reporter interface works with, so it always
+	// goes to stderr rather than competing with a --format=json/csv stdout.
+	outputMLFQOccupancy(os.Stderr, occupancy)
+
+	return metrics, gantt, nil
+}
+
+// outputMLFQOccupancy prints how many ticks of CPU time each queue level
+// served, as a quick sanity check that lower-priority jobs aren't starving.
+func outputMLFQOccupancy(w io.Writer, occupancy []int64) {
+	var total int64
+	for _, o := range occupancy {
+		total += o
+	}
+
+	_, _ = fmt.Fprintln(w, "Queue occupancy")
+	for lvl, o := range occupancy {
+		pct := 0.0
+		if total > 0 {
+			pct = 100 * float64(o) / float64(total)
+		}
+		_, _ = fmt.Fprintf(w, "  level %d: %d ticks (%.1f%%)\n", lvl, o, pct)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+//endregion
+
+//region Output helpers
+
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+func outputGantt(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	_, _ = fmt.Fprint(w, "|")
+	for i := range gantt {
+		pid := fmt.Sprint(gantt[i].PID)
+		padding := strings.Repeat(" ", (8-len(pid))/2)
+		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+	}
+	_, _ = fmt.Fprintln(w)
+	for i := range gantt {
+		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
+		if len(gantt)-1 == i {
+			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n\n")
+}
+
+func outputSchedule(w io.Writer, rows [][]string, metrics Metrics) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "",
+		fmt.Sprintf("Average\n%.2f", metrics.AveWait),
+		fmt.Sprintf("Average\n%.2f", metrics.AveTurnaround),
+		fmt.Sprintf("Throughput\n%.2f/t", metrics.Throughput)})
+	table.Render()
+
+	_, _ = fmt.Fprintf(w, "Response: %.2f avg  |  CPU utilization: %.1f%%  |  Context switches: %d\n",
+		metrics.AveResponse, metrics.CPUUtilization*100, metrics.ContextSwitches)
+}
+
+// unionTicks returns the number of ticks covered by slices, counting
+// overlapping or nested slices only once.
+func unionTicks(slices []TimeSlice) int64 {
+	if len(slices) == 0 {
+		return 0
+	}
+
+	sorted := append([]TimeSlice(nil), slices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var total int64
+	curStart, curEnd := sorted[0].Start, sorted[0].Stop
+	for _, ts := range sorted[1:] {
+		if ts.Start <= curEnd {
+			if ts.Stop > curEnd {
+				curEnd = ts.Stop
+			}
+			continue
+		}
+		total += curEnd - curStart
+		curStart, curEnd = ts.Start, ts.Stop
+	}
+	total += curEnd - curStart
+
+	return total
+}
+
+// computeMetrics derives the summary statistics for a run from the pieces
+// each scheduler already tracks: the rendered schedule rows (for
+// turnaround), the gantt chart (for response time, CPU utilization, and
+// context switches), the running turnaround total, and the last
+// completion time. Waits are derived from the turnarounds and bursts.
+func computeMetrics(processes []Process, schedule [][]string, gantt []TimeSlice, totalTurnaround, lastCompletion float64) Metrics {
+	count := float64(len(processes))
+
+	turnarounds := make([]int64, len(schedule))
+	for i, row := range schedule {
+		if len(row) > 5 {
+			t, _ := strconv.ParseInt(row[5], 10, 64)
+			turnarounds[i] = t
+		}
+	}
+
+	// Idle/context-switch-overhead slices (PID < 0) aren't process work:
+	// they shouldn't inflate the switch count or the busy-time sum below.
+	var work []TimeSlice
+	for _, ts := range gantt {
+		if ts.PID >= 0 {
+			work = append(work, ts)
+		}
+	}
+
+	contextSwitches := 0
+	for i := 1; i < len(work); i++ {
+		if work[i].PID != work[i-1].PID {
+			contextSwitches++
+		}
+	}
+
+	// Preempted processes' slices can overlap (a resumed slice spans its
+	// original start to the current service time), so busy ticks must be
+	// the union of the slices, not their naive sum.
+	busy := unionTicks(work)
+
+	cpuUtilization := 0.0
+	if len(processes) > 0 {
+		minArrival := processes[0].ArrivalTime
+		for _, p := range processes {
+			if p.ArrivalTime < minArrival {
+				minArrival = p.ArrivalTime
+			}
+		}
+		// The makespan is the end of the last busy tick, not the completion
+		// of whichever process happened to finish last in iteration order
+		// (which can precede other processes' completions and overstate
+		// utilization).
+		var makespan int64
+		for _, ts := range work {
+			if ts.Stop > makespan {
+				makespan = ts.Stop
+			}
+		}
+		if span := float64(makespan - minArrival); span > 0 {
+			cpuUtilization = float64(busy) / span
+		}
+	}
+
+	var totalResponse float64
+	for _, p := range processes {
+		first := int64(-1)
+		for _, ts := range gantt {
+			if ts.PID == p.ProcessID && (first == -1 || ts.Start < first) {
+				first = ts.Start
+			}
+		}
+		if first >= 0 {
+			totalResponse += float64(first - p.ArrivalTime)
+		}
+	}
+
+	var totalWait float64
+	for i, t := range turnarounds {
+		if i < len(processes) {
+			totalWait += float64(t - processes[i].BurstDuration)
+		}
+	}
+
+	return Metrics{
+		AveWait:         totalWait / count,
+		AveTurnaround:   totalTurnaround / count,
+		Throughput:      count / lastCompletion,
+		AveResponse:     totalResponse / count,
+		CPUUtilization:  cpuUtilization,
+		ContextSwitches: contextSwitches,
+		Turnarounds:     turnarounds,
+	}
+}
+
+// outputHistogram renders an ASCII histogram of turnaround times bucketed
+// into power-of-two ranges, in the style of benchmark tools that print a
+// latency distribution alongside the headline average.
+func outputHistogram(w io.Writer, turnarounds []int64) {
+	if len(turnarounds) == 0 {
+		return
+	}
+
+	var max int64
+	for _, t := range turnarounds {
+		if t > max {
+			max = t
+		}
+	}
+
+	var buckets []int64
+	for b := int64(1); b/2 <= max || len(buckets) == 0; b *= 2 {
+		buckets = append(buckets, b)
+	}
+
+	counts := make([]int, len(buckets))
+	for _, t := range turnarounds {
+		for i, b := range buckets {
+			if t <= b {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Turnaround histogram")
+	for i, b := range buckets {
+		lo := int64(0)
+		if i > 0 {
+			lo = buckets[i-1] + 1
+		}
+		bar := strings.Repeat("#", counts[i])
+		_, _ = fmt.Fprintf(w, "  [%4d, %4d]\t%-20s %d\n", lo, b, bar, counts[i])
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+//endregion
+
+//region Reporters
+
+// Reporter renders one algorithm's run. Implementations may write
+// immediately (TextReporter) or buffer until Flush so every run can be
+// emitted together (JSONReporter, CSVReporter).
+type Reporter interface {
+	Report(w io.Writer, name string, processes []Process, gantt []TimeSlice, metrics Metrics)
+	Flush(w io.Writer) error
+}
+
+// newReporter builds the Reporter named by a --format flag value.
+func newReporter(format string) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return TextReporter{}, nil
+	case "json":
+		return &JSONReporter{}, nil
+	case "csv":
+		return &CSVReporter{}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown --format %q", ErrInvalidArgs, format)
+	}
+}
+
+// scheduleRows rebuilds the schedule table rows (ID, Priority, Burst,
+// Arrival, Wait, Turnaround, Exit) from a run's processes and Metrics.
+// Metrics.Turnarounds is indexed in the same order as processes, so the
+// rest of each row can be derived without re-running the scheduler.
+func scheduleRows(processes []Process, metrics Metrics) [][]string {
+	rows := make([][]string, len(processes))
+	for i, p := range processes {
+		turnaround := metrics.Turnarounds[i]
+		wait := turnaround - p.BurstDuration
+		completion := p.ArrivalTime + turnaround
+
+		rows[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(wait),
+			fmt.Sprint(turnaround),
+			fmt.Sprint(completion),
+		}
+	}
+	return rows
+}
+
+// TextReporter renders each run immediately in the original Gantt chart +
+// table + histogram layout.
+type TextReporter struct{}
+
+func (TextReporter) Report(w io.Writer, name string, processes []Process, gantt []TimeSlice, metrics Metrics) {
+	outputTitle(w, name)
+	outputGantt(w, gantt)
+	outputSchedule(w, scheduleRows(processes, metrics), metrics)
+	outputHistogram(w, metrics.Turnarounds)
+}
+
+func (TextReporter) Flush(io.Writer) error { return nil }
+
+// jsonRun is the JSON document emitted per algorithm by JSONReporter.
+type jsonRun struct {
+	Algorithm string      `json:"algorithm"`
+	Processes []Process   `json:"processes"`
+	Gantt     []TimeSlice `json:"gantt"`
+	Metrics   Metrics     `json:"metrics"`
+}
+
+// JSONReporter buffers every run and, on Flush, emits them as a single
+// top-level JSON array so downstream tooling can diff algorithms against
+// each other in one document.
+type JSONReporter struct {
+	runs []jsonRun
+}
+
+func (r *JSONReporter) Report(w io.Writer, name string, processes []Process, gantt []TimeSlice, metrics Metrics) {
+	r.runs = append(r.runs, jsonRun{Algorithm: name, Processes: processes, Gantt: gantt, Metrics: metrics})
+}
+
+func (r *JSONReporter) Flush(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.runs)
+}
+
+// CSVReporter buffers every run's schedule rows, tagged with the
+// algorithm name, and writes them as one CSV table on Flush.
+type CSVReporter struct {
+	rows [][]string
+}
+
+func (r *CSVReporter) Report(w io.Writer, name string, processes []Process, gantt []TimeSlice, metrics Metrics) {
+	for _, row := range scheduleRows(processes, metrics) {
+		r.rows = append(r.rows, append([]string{name}, row...))
+	}
+}
+
+func (r *CSVReporter) Flush(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"Algorithm", "ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(r.rows); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//endregion
+
+//region Comparison
+
+// AlgorithmRun bundles an algorithm's name with its computed Metrics, for
+// CompareRuns to rank.
+type AlgorithmRun struct {
+	Name    string
+	Metrics Metrics
+}
+
+// compareColumn describes one column of the comparison table: how to
+// read the value out of a run, how "better" is defined, and how to
+// format it for display.
+type compareColumn struct {
+	header         string
+	value          func(Metrics) float64
+	higherIsBetter bool
+	format         func(float64) string
+}
+
+var compareColumns = []compareColumn{
+	{"Avg Wait", func(m Metrics) float64 { return m.AveWait }, false, func(v float64) string { return fmt.Sprintf("%.2f", v) }},
+	{"Avg Turnaround", func(m Metrics) float64 { return m.AveTurnaround }, false, func(v float64) string { return fmt.Sprintf("%.2f", v) }},
+	{"Throughput", func(m Metrics) float64 { return m.Throughput }, true, func(v float64) string { return fmt.Sprintf("%.2f/t", v) }},
+	{"CPU Util", func(m Metrics) float64 { return m.CPUUtilization }, true, func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) }},
+	{"Ctx Switches", func(m Metrics) float64 { return float64(m.ContextSwitches) }, false, func(v float64) string { return fmt.Sprintf("%.0f", v) }},
+}
+
+// rankKeys maps a --rank-by flag value to the compareColumns index it sorts by.
+var rankKeys = map[string]int{
+	"wait":        0,
+	"turnaround":  1,
+	"throughput":  2,
+	"utilization": 3,
+	"switches":    4,
+}
+
+// CompareRuns renders a single table with one row per algorithm, columns
+// for avg wait, avg turnaround, throughput, CPU utilization, and context
+// switches, and a `*` marking the best value in each column. Rows are
+// sorted by rankBy (one of the keys in rankKeys; "wait" if empty or unknown).
+func CompareRuns(w io.Writer, runs []AlgorithmRun, rankBy string) {
+	if len(runs) == 0 {
+		return
+	}
+
+	col, ok := rankKeys[rankBy]
+	if !ok {
+		col = rankKeys["wait"]
+	}
+
+	sorted := make([]AlgorithmRun, len(runs))
+	copy(sorted, runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		vi, vj := compareColumns[col].value(sorted[i].Metrics), compareColumns[col].value(sorted[j].Metrics)
+		if compareColumns[col].higherIsBetter {
+			return vi > vj
+		}
+		return vi < vj
+	})
+
+	best := make([]float64, len(compareColumns))
+	for c, column := range compareColumns {
+		best[c] = column.value(sorted[0].Metrics)
+		for _, r := range sorted[1:] {
+			v := column.value(r.Metrics)
+			if (column.higherIsBetter && v > best[c]) || (!column.higherIsBetter && v < best[c]) {
+				best[c] = v
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Comparison")
+	table := tablewriter.NewWriter(w)
+	header := make([]string, len(compareColumns)+1)
+	header[0] = "Algorithm"
+	for c, column := range compareColumns {
+		header[c+1] = column.header
+	}
+	table.SetHeader(header)
+
+	for _, r := range sorted {
+		row := make([]string, len(compareColumns)+1)
+		row[0] = r.Name
+		for c, column := range compareColumns {
+			v := column.value(r.Metrics)
+			cell := column.format(v)
+			if v == best[c] {
+				cell += " *"
+			}
+			row[c+1] = cell
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+//endregion
+
+//region Loading processes.
+
+var ErrInvalidArgs = errors.New("invalid args")
+
+func loadProcesses(r io.Reader) ([]Process, error) {
+	csvReader := csv.NewReader(r)
+	// Rows may optionally carry a 5th (I/O burst) column, so don't require
+	// every row to have the same field count.
+	csvReader.FieldsPerRecord = -1
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	processes := make([]Process, len(rows))
+	for i := range rows {
+		processes[i].ProcessID = mustStrToInt(rows[i][0])
+		processes[i].BurstDuration = mustStrToInt(rows[i][1])
+		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
+		if len(rows[i]) >= 4 {
+			processes[i].Priority = mustStrToInt(rows[i][3])
+		}
+		if len(rows[i]) >= 5 && rows[i][4] != "" {
+			processes[i].IOBursts = parseIOBursts(rows[i][4])
+
+			// Keep BurstDuration equal to the sum of the CPU phases so the
+			// schedulers, which all treat BurstDuration as the process' total
+			// CPU time (SJF, priority, and round-robin compute ticks-executed
+			// as BurstDuration-procTimes[j]), don't need to special-case it.
+			var cpuTicks int64
+			for phase := 0; phase < len(processes[i].IOBursts); phase += 2 {
+				cpuTicks += processes[i].IOBursts[phase]
+			}
+			processes[i].BurstDuration = cpuTicks
+		}
+	}
+
+	return processes, nil
+}
+
+// parseIOBursts parses a ";"-separated list of alternating CPU/IO phase
+// durations, e.g. "4;3;2" is CPU 4, IO 3, CPU 2.
+func parseIOBursts(s string) []int64 {
+	parts := strings.Split(s, ";")
+	bursts := make([]int64, len(parts))
+	for i, p := range parts {
+		bursts[i] = mustStrToInt(p)
+	}
+	return bursts
+}
+
+func mustStrToInt(s string) int64 {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	return i
+}
+
+//endregion
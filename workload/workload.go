@@ -0,0 +1,98 @@
+// Package workload generates synthetic process sets for benchmarking the
+// schedulers in main, instead of requiring a hand-authored CSV.
+package workload
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Process mirrors the scheduler's process record. It's kept independent of
+// package main (an import cycle isn't possible the other way) so main can
+// convert the generated slice into its own Process type.
+type Process struct {
+	ProcessID     int64
+	ArrivalTime   int64
+	BurstDuration int64
+	Priority      int64
+	// IOBursts, when set, alternates CPU and I/O phases (CPU, IO, CPU, ...)
+	// in place of one uninterrupted BurstDuration run; see IOBurstProb.
+	IOBursts []int64
+}
+
+// WorkloadConfig parameterizes GenerateProcesses.
+type WorkloadConfig struct {
+	// N is the number of processes to generate.
+	N int
+	// Seed makes the run reproducible; the same seed always yields the same processes.
+	Seed int64
+	// ArrivalRate is λ, the Poisson rate used to sample interarrival times.
+	ArrivalRate float64
+	// MeanBurst is the mean of the exponential distribution used to sample burst durations.
+	MeanBurst float64
+	// PriorityLevels, if > 0, assigns each process a uniform-random priority in [0, PriorityLevels).
+	PriorityLevels int
+	// IOBurstProb is the probability a process' burst is split into CPU, IO,
+	// CPU phases instead of one uninterrupted run, with the IO phase drawn
+	// from the same exponential distribution as MeanBurst.
+	IOBurstProb float64
+}
+
+// GenerateProcesses produces cfg.N processes with Poisson arrivals and
+// exponential burst times, seeded deterministically so the same
+// WorkloadConfig always yields the same workload.
+//
+// Interarrival times are sampled as -ln(1-U)/λ and burst durations as
+// -ln(1-U)*MeanBurst, the standard inverse-transform draws for an
+// exponential distribution.
+func GenerateProcesses(cfg WorkloadConfig) []Process {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	processes := make([]Process, cfg.N)
+	var arrival int64
+	for i := 0; i < cfg.N; i++ {
+		if i > 0 {
+			arrival += sampleExponential(rng, cfg.ArrivalRate)
+		}
+
+		burst := sampleExponential(rng, 1/cfg.MeanBurst)
+		if burst < 1 {
+			burst = 1
+		}
+
+		var priority int64
+		if cfg.PriorityLevels > 0 {
+			priority = rng.Int63n(int64(cfg.PriorityLevels))
+		}
+
+		var ioBursts []int64
+		if cfg.IOBurstProb > 0 && burst > 1 && rng.Float64() < cfg.IOBurstProb {
+			// Split the CPU burst around a random midpoint and insert an IO
+			// phase of its own, leaving BurstDuration as the CPU-only total.
+			split := 1 + rng.Int63n(burst-1)
+			ioBurst := sampleExponential(rng, 1/cfg.MeanBurst)
+			ioBursts = []int64{split, ioBurst, burst - split}
+		}
+
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   arrival,
+			BurstDuration: burst,
+			Priority:      priority,
+			IOBursts:      ioBursts,
+		}
+	}
+
+	return processes
+}
+
+// sampleExponential draws from an exponential distribution with the given
+// rate using inverse-transform sampling, rounded to a whole tick.
+func sampleExponential(rng *rand.Rand, rate float64) int64 {
+	if rate <= 0 {
+		return 1
+	}
+	u := rng.Float64()
+	v := -math.Log(1-u) / rate
+	return int64(math.Round(v))
+}